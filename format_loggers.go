@@ -0,0 +1,229 @@
+package genmai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logfmtArgValue renders arg as a single logfmt-safe token: numbers and
+// bools print as-is, time.Time as RFC3339, []byte as base64, and strings are
+// quoted only when they contain characters that would break logfmt parsing.
+func logfmtArgValue(arg interface{}) string {
+	switch v := arg.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case string:
+		return logfmtQuote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// logfmtQuote quotes s with strconv.Quote if it contains characters that
+// would otherwise break logfmt's key=value parsing.
+func logfmtQuote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// JSONLogger is a logger that emits one JSON object per query.
+// It implements the logger and columnLogger interfaces.
+type JSONLogger struct {
+	w  io.Writer
+	m  sync.Mutex
+	s  float64
+	mc []string
+}
+
+// NewJSONLogger returns a new JSONLogger that writes one JSON object per
+// line to w.
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{w: w}
+}
+
+// SetFormat is unused by JSONLogger: the record shape is fixed so every
+// line is valid JSON. It always returns nil.
+func (l *JSONLogger) SetFormat(format string) error {
+	return nil
+}
+
+// SetSlowTime sets slow query threshold.
+func (l *JSONLogger) SetSlowTime(slow float64) error {
+	l.s = slow
+	return nil
+}
+
+// AddColumnMask sets column to be masked.
+func (l *JSONLogger) AddColumnMask(mask string) {
+	l.mc = append(l.mc, mask)
+}
+
+// RemoveColumnMask remove column to be masked.
+func (l *JSONLogger) RemoveColumnMask(mask string) {
+	for i, v := range l.mc {
+		if v == mask {
+			l.mc = append(l.mc[:i], l.mc[i+1:]...)
+			return
+		}
+	}
+}
+
+// jsonRecord is the JSON shape of a single query log line.
+type jsonRecord struct {
+	Time       time.Time     `json:"ts"`
+	DurationMs float64       `json:"duration_ms"`
+	Query      string        `json:"query"`
+	Args       []interface{} `json:"args"`
+	Masked     bool          `json:"masked"`
+	Slow       bool          `json:"slow"`
+}
+
+// Print outputs a query as a single JSON object.
+func (l *JSONLogger) Print(start time.Time, query string, args ...interface{}) error {
+	return l.print(start, query, maskColumnsByRegex(query, l.mc), args...)
+}
+
+// PrintColumns is like Print, but masks args by matching columns against the
+// secret columns registered via AddColumnMask/RegisterModel, instead of
+// re-parsing query with regexes.
+func (l *JSONLogger) PrintColumns(start time.Time, query string, columns []string, args ...interface{}) error {
+	if len(columns) == 0 {
+		return l.Print(start, query, args...)
+	}
+	return l.print(start, query, maskColumnsByName(columns, l.mc), args...)
+}
+
+func (l *JSONLogger) print(start time.Time, query string, to_mask []int, args ...interface{}) error {
+	duration := now().Sub(start).Seconds() * 1000.0
+
+	values := []interface{}{}
+	masked := false
+	if len(args) > 0 {
+		values = make([]interface{}, len(args))
+		for i, arg := range args {
+			if len(to_mask) > 0 && to_mask[0] == i {
+				values[i] = "* SECRET *"
+				to_mask = to_mask[1:]
+				masked = true
+			} else {
+				// encoding/json already renders time.Time as RFC3339 and
+				// []byte as base64, so arg needs no further conversion.
+				values[i] = arg
+			}
+		}
+	}
+
+	b, err := json.Marshal(jsonRecord{
+		Time:       start,
+		DurationMs: duration,
+		Query:      query,
+		Args:       values,
+		Masked:     masked,
+		Slow:       l.s > 0.0 && duration >= l.s,
+	})
+	if err != nil {
+		return err
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	_, err = fmt.Fprintln(l.w, string(b))
+	return err
+}
+
+// LogfmtLogger is a logger that emits one logfmt line per query.
+// It implements the logger and columnLogger interfaces.
+type LogfmtLogger struct {
+	w  io.Writer
+	m  sync.Mutex
+	s  float64
+	mc []string
+}
+
+// NewLogfmtLogger returns a new LogfmtLogger that writes one logfmt line per
+// query to w.
+func NewLogfmtLogger(w io.Writer) *LogfmtLogger {
+	return &LogfmtLogger{w: w}
+}
+
+// SetFormat is unused by LogfmtLogger: the key order and set are fixed. It
+// always returns nil.
+func (l *LogfmtLogger) SetFormat(format string) error {
+	return nil
+}
+
+// SetSlowTime sets slow query threshold.
+func (l *LogfmtLogger) SetSlowTime(slow float64) error {
+	l.s = slow
+	return nil
+}
+
+// AddColumnMask sets column to be masked.
+func (l *LogfmtLogger) AddColumnMask(mask string) {
+	l.mc = append(l.mc, mask)
+}
+
+// RemoveColumnMask remove column to be masked.
+func (l *LogfmtLogger) RemoveColumnMask(mask string) {
+	for i, v := range l.mc {
+		if v == mask {
+			l.mc = append(l.mc[:i], l.mc[i+1:]...)
+			return
+		}
+	}
+}
+
+// Print outputs a query as a single logfmt line.
+func (l *LogfmtLogger) Print(start time.Time, query string, args ...interface{}) error {
+	return l.print(start, query, maskColumnsByRegex(query, l.mc), args...)
+}
+
+// PrintColumns is like Print, but masks args by matching columns against the
+// secret columns registered via AddColumnMask/RegisterModel, instead of
+// re-parsing query with regexes.
+func (l *LogfmtLogger) PrintColumns(start time.Time, query string, columns []string, args ...interface{}) error {
+	if len(columns) == 0 {
+		return l.Print(start, query, args...)
+	}
+	return l.print(start, query, maskColumnsByName(columns, l.mc), args...)
+}
+
+func (l *LogfmtLogger) print(start time.Time, query string, to_mask []int, args ...interface{}) error {
+	duration := now().Sub(start).Seconds() * 1000.0
+
+	masked := false
+	argParts := make([]string, len(args))
+	for i, arg := range args {
+		if len(to_mask) > 0 && to_mask[0] == i {
+			argParts[i] = "* SECRET *"
+			to_mask = to_mask[1:]
+			masked = true
+		} else {
+			argParts[i] = logfmtArgValue(arg)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "ts=%s duration_ms=%.2f query=%s args=%s masked=%t slow=%t",
+		start.Format(time.RFC3339Nano), duration, logfmtQuote(query),
+		logfmtQuote("["+strings.Join(argParts, ", ")+"]"), masked, l.s > 0.0 && duration >= l.s)
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	_, err := fmt.Fprintln(l.w, buf.String())
+	return err
+}
@@ -0,0 +1,148 @@
+package genmai
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONLoggerShape(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []interface{}
+		slow  float64
+		delay time.Duration
+		want  jsonRecord
+	}{
+		{
+			name:  "no args",
+			args:  nil,
+			delay: 5 * time.Millisecond,
+			want:  jsonRecord{Query: "SELECT 1", Args: []interface{}{}, Masked: false, Slow: false},
+		},
+		{
+			name:  "with args",
+			args:  []interface{}{1},
+			delay: 5 * time.Millisecond,
+			want:  jsonRecord{Query: "SELECT 1", Args: []interface{}{float64(1)}, Masked: false, Slow: false},
+		},
+		{
+			name:  "slow",
+			args:  nil,
+			slow:  1,
+			delay: 5 * time.Millisecond,
+			want:  jsonRecord{Query: "SELECT 1", Args: []interface{}{}, Masked: false, Slow: true},
+		},
+		{
+			name:  "below threshold still logged",
+			args:  nil,
+			slow:  100,
+			delay: 5 * time.Millisecond,
+			want:  jsonRecord{Query: "SELECT 1", Args: []interface{}{}, Masked: false, Slow: false},
+		},
+	}
+
+	restore := now
+	defer func() { now = restore }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewJSONLogger(&buf)
+			if tt.slow > 0 {
+				l.SetSlowTime(tt.slow)
+			}
+			start := time.Unix(0, 0)
+			now = func() time.Time { return start.Add(tt.delay) }
+
+			if err := l.Print(start, tt.want.Query, tt.args...); err != nil {
+				t.Fatalf("Print() error = %v", err)
+			}
+
+			var got jsonRecord
+			if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &got); err != nil {
+				t.Fatalf("output is not valid JSON: %v (%q)", err, buf.String())
+			}
+			got.Time = time.Time{}
+			got.DurationMs = 0
+			if got.Query != tt.want.Query || got.Masked != tt.want.Masked || got.Slow != tt.want.Slow {
+				t.Errorf("got = %+v, want %+v", got, tt.want)
+			}
+			if len(got.Args) != len(tt.want.Args) {
+				t.Errorf("Args = %v, want %v", got.Args, tt.want.Args)
+			}
+		})
+	}
+}
+
+func TestJSONLoggerMasksSecretColumns(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger(&buf)
+	l.AddColumnMask("password")
+
+	restore := now
+	now = func() time.Time { return time.Unix(0, 0) }
+	defer func() { now = restore }()
+
+	err := l.PrintColumns(time.Unix(0, 0), "UPDATE users SET password = ? WHERE id = ?",
+		[]string{"password", "id"}, "hunter2", 1)
+	if err != nil {
+		t.Fatalf("PrintColumns() error = %v", err)
+	}
+	var rec jsonRecord
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &rec); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if !rec.Masked {
+		t.Errorf("expected Masked = true, got false")
+	}
+	if rec.Args[0] != "* SECRET *" {
+		t.Errorf("Args[0] = %v, want masked placeholder", rec.Args[0])
+	}
+	if rec.Args[1] != float64(1) {
+		t.Errorf("Args[1] = %v, want 1", rec.Args[1])
+	}
+}
+
+func TestLogfmtLoggerShape(t *testing.T) {
+	restore := now
+	now = func() time.Time { return time.Unix(0, 0).Add(5 * time.Millisecond) }
+	defer func() { now = restore }()
+
+	var buf bytes.Buffer
+	l := NewLogfmtLogger(&buf)
+	if err := l.Print(time.Unix(0, 0), "SELECT 1"); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	out := buf.String()
+	for _, key := range []string{"ts=", "duration_ms=", "query=", "args=", "masked=false", "slow=false"} {
+		if !strings.Contains(out, key) {
+			t.Errorf("expected output to contain %q, got %q", key, out)
+		}
+	}
+	if !strings.Contains(out, "args=[]") {
+		t.Errorf("expected empty args to render as args=[], got %q", out)
+	}
+}
+
+func TestLogfmtLoggerMasksSecretColumns(t *testing.T) {
+	restore := now
+	now = func() time.Time { return time.Unix(0, 0) }
+	defer func() { now = restore }()
+
+	var buf bytes.Buffer
+	l := NewLogfmtLogger(&buf)
+	l.AddColumnMask("password")
+	if err := l.Print(time.Unix(0, 0), "UPDATE `users` SET `password` = ?", "hunter2"); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("expected password arg to be masked, got %q", out)
+	}
+	if !strings.Contains(out, "masked=true") {
+		t.Errorf("expected masked=true, got %q", out)
+	}
+}
@@ -23,8 +23,44 @@ var (
 	insertRegex      = regexp.MustCompile(`INSERT.*\((.+?)\)\s*VALUES`)
 	insertParamRegex = regexp.MustCompile("`(\\w+?)`")
 	whereRegex       = regexp.MustCompile("`(\\w+?)`\\s*(?:=\\s*(\\?)|IN\\s*\\(([\\?\\s,]+)\\))")
+
+	queryOperationRegex = regexp.MustCompile(`(?i)^\s*(SELECT|INSERT|UPDATE|DELETE)\b`)
+	fromTableRegex      = regexp.MustCompile("(?i)FROM\\s*`?(\\w+)`?")
+	intoTableRegex      = regexp.MustCompile("(?i)INSERT\\s+INTO\\s*`?(\\w+)`?")
+	updateTableRegex    = regexp.MustCompile("(?i)UPDATE\\s*`?(\\w+)`?")
 )
 
+// queryOperation returns the SQL operation keyword (SELECT, INSERT, UPDATE or
+// DELETE) found at the head of query, upper-cased, or "" if query doesn't
+// start with one of them.
+func queryOperation(query string) string {
+	m := queryOperationRegex.FindStringSubmatch(query)
+	if len(m) < 2 {
+		return ""
+	}
+	return strings.ToUpper(m[1])
+}
+
+// queryTable returns the primary table name referenced by query, or "" if it
+// can't be determined. op is the result of queryOperation, used to pick the
+// right clause to look at.
+func queryTable(op, query string) string {
+	var re *regexp.Regexp
+	switch op {
+	case "INSERT":
+		re = intoTableRegex
+	case "UPDATE":
+		re = updateTableRegex
+	default:
+		re = fromTableRegex
+	}
+	m := re.FindStringSubmatch(query)
+	if len(m) < 2 {
+		return ""
+	}
+	return m[1]
+}
+
 // logger is the interface that query logger.
 type logger interface {
 	// Print outputs query log.
@@ -84,6 +120,17 @@ func (l *templateLogger) RemoveColumnMask(mask string) {
 }
 
 func (l *templateLogger) toMaskColumn(sql string) []int {
+	return maskColumnsByRegex(sql, l.mc)
+}
+
+// maskColumnsByRegex parses sql with the package-level regexes to recover
+// the column name bound to each "?" placeholder, in order, then returns the
+// indexes whose column is present in mc.
+//
+// Deprecated: this only understands backtick-quoted identifiers in a narrow
+// set of UPDATE/INSERT/WHERE shapes, so it silently misses double-quoted
+// identifiers, JOINs, subqueries, and IN (...) with mixed literals.
+func maskColumnsByRegex(sql string, mc []string) []int {
 	// Gather target columns from
 	s2 := splitWhereRegex.Split(string(sql), -1)
 
@@ -113,7 +160,7 @@ func (l *templateLogger) toMaskColumn(sql string) []int {
 
 	to_mask := []int{}
 	for i, c := range cols {
-		for _, m := range l.mc {
+		for _, m := range mc {
 			if c == m {
 				to_mask = append(to_mask, i)
 				break
@@ -127,9 +174,23 @@ func (l *templateLogger) toMaskColumn(sql string) []int {
 // Print outputs query log using format template.
 // All arguments will be used to formatting.
 func (l *templateLogger) Print(start time.Time, query string, args ...interface{}) error {
+	return l.print(start, query, l.toMaskColumn(query), args...)
+}
+
+// PrintColumns is like Print, but masks args by matching columns - the
+// column name bound to each placeholder, as recorded by the query builder -
+// against the secret columns registered via AddColumnMask/RegisterModel,
+// instead of re-parsing query with regexes.
+func (l *templateLogger) PrintColumns(start time.Time, query string, columns []string, args ...interface{}) error {
+	if len(columns) == 0 {
+		return l.Print(start, query, args...)
+	}
+	return l.print(start, query, maskColumnsByName(columns, l.mc), args...)
+}
+
+func (l *templateLogger) print(start time.Time, query string, to_mask []int, args ...interface{}) error {
 	if len(args) > 0 {
 		// Mask
-		to_mask := l.toMaskColumn(query)
 		values := make([]string, len(args))
 		for i, arg := range args {
 			if len(to_mask) > 0 && to_mask[0] == i {
@@ -186,3 +247,8 @@ func (l *nullLogger) RemoveColumnMask(mask string) {
 func (l *nullLogger) Print(start time.Time, query string, args ...interface{}) error {
 	return nil
 }
+
+// PrintColumns is a dummy method.
+func (l *nullLogger) PrintColumns(start time.Time, query string, columns []string, args ...interface{}) error {
+	return nil
+}
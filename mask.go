@@ -0,0 +1,82 @@
+package genmai
+
+import (
+	"reflect"
+	"time"
+)
+
+// columnLogger is an optional extension of logger for loggers that can mask
+// secret columns without re-parsing SQL. The query builder records, for
+// each generated statement, the ordered list of column names bound to each
+// "?" placeholder; a columnLogger masks args by matching that list against
+// its registered secret columns, so masking stays correct regardless of
+// dialect, JOINs, subqueries, or how identifiers are quoted. Prefer this
+// over the deprecated maskColumnsByRegex: it receives the exact column list
+// from the query builder instead of re-parsing SQL.
+type columnLogger interface {
+	logger
+
+	// PrintColumns is like Print, but additionally receives the column name
+	// bound to each element of args, in the same order. A nil or empty
+	// columns falls back to the behavior of Print.
+	PrintColumns(start time.Time, query string, columns []string, args ...interface{}) error
+}
+
+// genmaiTagSecret is the `genmai` struct tag value that marks a field as a
+// secret column, e.g. `db:"password" genmai:"secret"`.
+const genmaiTagSecret = "secret"
+
+// SecretColumns returns the db column names of t's fields tagged
+// `genmai:"secret"`. t may be a struct type or a pointer to one.
+func SecretColumns(t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var cols []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if IsUnexportedField(field) {
+			continue
+		}
+		if field.Tag.Get("genmai") != genmaiTagSecret {
+			continue
+		}
+		name := field.Tag.Get("db")
+		if name == "" {
+			name = field.Name
+		}
+		cols = append(cols, name)
+	}
+	return cols
+}
+
+// RegisterModel scans v - a struct or pointer to one - for `genmai:"secret"`
+// tags and registers each matching db column with l via AddColumnMask. The
+// ORM calls this at model-registration time so masking doesn't need a
+// manual AddColumnMask call per model.
+func RegisterModel(l logger, v interface{}) {
+	for _, col := range SecretColumns(reflect.TypeOf(v)) {
+		l.AddColumnMask(col)
+	}
+}
+
+// maskColumnsByName returns, in order, the indexes of columns whose name is
+// registered in mc. Unlike maskColumnsByRegex, columns is the exact list the
+// query builder bound to each placeholder, so this is correct for every
+// dialect and SQL shape instead of only backtick-quoted UPDATE/INSERT/WHERE.
+func maskColumnsByName(columns []string, mc []string) []int {
+	set := make(map[string]struct{}, len(mc))
+	for _, m := range mc {
+		set[m] = struct{}{}
+	}
+	var to_mask []int
+	for i, c := range columns {
+		if _, ok := set[c]; ok {
+			to_mask = append(to_mask, i)
+		}
+	}
+	return to_mask
+}
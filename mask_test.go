@@ -0,0 +1,79 @@
+package genmai
+
+import (
+	"reflect"
+	"testing"
+)
+
+type maskTestUser struct {
+	ID       int64  `db:"id"`
+	Name     string `db:"name"`
+	Password string `db:"password" genmai:"secret"`
+	Token    string `db:"auth_token" genmai:"secret"`
+	internal string
+}
+
+func TestSecretColumns(t *testing.T) {
+	got := SecretColumns(reflect.TypeOf(maskTestUser{}))
+	want := []string{"password", "auth_token"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SecretColumns() = %v, want %v", got, want)
+	}
+}
+
+func TestSecretColumnsPointer(t *testing.T) {
+	got := SecretColumns(reflect.TypeOf(&maskTestUser{}))
+	want := []string{"password", "auth_token"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SecretColumns() = %v, want %v", got, want)
+	}
+}
+
+func TestSecretColumnsNonStruct(t *testing.T) {
+	if got := SecretColumns(reflect.TypeOf("not a struct")); got != nil {
+		t.Errorf("SecretColumns() = %v, want nil", got)
+	}
+}
+
+func TestRegisterModel(t *testing.T) {
+	l := &templateLogger{}
+	RegisterModel(l, maskTestUser{})
+	want := []string{"password", "auth_token"}
+	if !reflect.DeepEqual(l.mc, want) {
+		t.Errorf("RegisterModel() registered masks = %v, want %v", l.mc, want)
+	}
+}
+
+func TestMaskColumnsByName(t *testing.T) {
+	tests := []struct {
+		columns []string
+		mc      []string
+		want    []int
+	}{
+		{
+			columns: []string{"name", "password", "email"},
+			mc:      []string{"password"},
+			want:    []int{1},
+		},
+		{
+			columns: []string{"password", "name", "auth_token"},
+			mc:      []string{"password", "auth_token"},
+			want:    []int{0, 2},
+		},
+		{
+			columns: []string{"name", "email"},
+			mc:      []string{"password"},
+			want:    nil,
+		},
+		{
+			columns: nil,
+			mc:      []string{"password"},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		if got := maskColumnsByName(tt.columns, tt.mc); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("maskColumnsByName(%v, %v) = %v, want %v", tt.columns, tt.mc, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package genmai
+
+import (
+	"context"
+	"time"
+)
+
+// QueryObserver is the interface for observing query execution. Unlike
+// logger, which only sees a finished query, an observer is notified both
+// before and after execution so it can carry state - a tracing span, a
+// metrics timer - across the call.
+//
+// A *DB can register any number of observers; they are invoked in
+// registration order to form a single, ordered dispatch pipeline.
+type QueryObserver interface {
+	// BeforeQuery is called before a query is executed. The returned
+	// context, if non-nil, replaces ctx for the remainder of the call and
+	// is the ctx passed to AfterQuery.
+	BeforeQuery(ctx context.Context, query string, args []interface{}) context.Context
+
+	// AfterQuery is called after a query has finished, whether or not it
+	// succeeded. rowsAffected is -1 when the query doesn't produce one
+	// (e.g. a SELECT). columns is the ordered list of column names bound to
+	// each element of args, as recorded by the query builder, for observers
+	// that need to mask or label by column; it is nil when unavailable.
+	AfterQuery(ctx context.Context, query string, columns []string, args []interface{}, rowsAffected int64, err error, duration time.Duration)
+}
+
+// observers dispatches to a list of QueryObserver in registration order. It
+// satisfies QueryObserver itself so callers can treat "no observers", "one
+// observer" and "many observers" the same way.
+type observers []QueryObserver
+
+// BeforeQuery calls BeforeQuery on each observer in order, threading the
+// returned context through to the next one.
+func (os observers) BeforeQuery(ctx context.Context, query string, args []interface{}) context.Context {
+	for _, o := range os {
+		ctx = o.BeforeQuery(ctx, query, args)
+	}
+	return ctx
+}
+
+// AfterQuery calls AfterQuery on each observer in order.
+func (os observers) AfterQuery(ctx context.Context, query string, columns []string, args []interface{}, rowsAffected int64, err error, duration time.Duration) {
+	for _, o := range os {
+		o.AfterQuery(ctx, query, columns, args, rowsAffected, err, duration)
+	}
+}
+
+// loggerObserver adapts a logger to QueryObserver so the existing
+// template/slog/JSON/logfmt loggers can be registered through the same
+// ordered dispatch pipeline as any other observer.
+type loggerObserver struct {
+	l logger
+}
+
+// AsObserver re-expresses l as a QueryObserver. Its BeforeQuery is a no-op
+// since logger only ever reports finished queries.
+func AsObserver(l logger) QueryObserver {
+	return &loggerObserver{l: l}
+}
+
+func (o *loggerObserver) BeforeQuery(ctx context.Context, query string, args []interface{}) context.Context {
+	return ctx
+}
+
+func (o *loggerObserver) AfterQuery(ctx context.Context, query string, columns []string, args []interface{}, rowsAffected int64, err error, duration time.Duration) {
+	if cl, ok := o.l.(columnLogger); ok {
+		cl.PrintColumns(now().Add(-duration), query, columns, args...)
+		return
+	}
+	o.l.Print(now().Add(-duration), query, args...)
+}
+
+// QueryOperation returns the SQL operation keyword (SELECT, INSERT, UPDATE
+// or DELETE) at the head of query, upper-cased, or "" if query doesn't start
+// with one of them. It is exported so QueryObserver implementations can
+// label spans and metrics (e.g. a Prometheus histogram per operation/table)
+// the same way the slog logger backend does.
+func QueryOperation(query string) string {
+	return queryOperation(query)
+}
+
+// QueryTable returns the primary table name referenced by query, or "" if
+// it can't be determined. op is the result of QueryOperation, used to pick
+// the right clause to look at.
+func QueryTable(op, query string) string {
+	return queryTable(op, query)
+}
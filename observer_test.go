@@ -0,0 +1,116 @@
+package genmai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type observerCtxKey string
+
+// recordingObserver appends its name to before/after in call order and
+// stamps a distinct value into the context it returns from BeforeQuery, so
+// tests can check both ordering and context threading.
+type recordingObserver struct {
+	name   string
+	before *[]string
+	after  *[]string
+}
+
+func (o *recordingObserver) BeforeQuery(ctx context.Context, query string, args []interface{}) context.Context {
+	*o.before = append(*o.before, o.name)
+	return context.WithValue(ctx, observerCtxKey(o.name), true)
+}
+
+func (o *recordingObserver) AfterQuery(ctx context.Context, query string, columns []string, args []interface{}, rowsAffected int64, err error, duration time.Duration) {
+	*o.after = append(*o.after, o.name)
+}
+
+func TestObserversDispatchInRegistrationOrder(t *testing.T) {
+	var before, after []string
+	a := &recordingObserver{name: "a", before: &before, after: &after}
+	b := &recordingObserver{name: "b", before: &before, after: &after}
+	os := observers{a, b}
+
+	ctx := os.BeforeQuery(context.Background(), "SELECT 1", nil)
+	if want := []string{"a", "b"}; !reflect.DeepEqual(before, want) {
+		t.Errorf("BeforeQuery order = %v, want %v", before, want)
+	}
+	if ctx.Value(observerCtxKey("a")) != true || ctx.Value(observerCtxKey("b")) != true {
+		t.Errorf("expected both observers' context values threaded through, got %v", ctx)
+	}
+
+	os.AfterQuery(ctx, "SELECT 1", nil, nil, -1, nil, time.Millisecond)
+	if want := []string{"a", "b"}; !reflect.DeepEqual(after, want) {
+		t.Errorf("AfterQuery order = %v, want %v", after, want)
+	}
+}
+
+// plainLogger implements only logger, not columnLogger, so it exercises
+// loggerObserver's fallback branch in AfterQuery.
+type plainLogger struct {
+	buf bytes.Buffer
+	mc  []string
+}
+
+func (l *plainLogger) SetFormat(format string) error  { return nil }
+func (l *plainLogger) SetSlowTime(slow float64) error { return nil }
+func (l *plainLogger) AddColumnMask(mask string)      { l.mc = append(l.mc, mask) }
+func (l *plainLogger) RemoveColumnMask(mask string)   {}
+
+func (l *plainLogger) Print(start time.Time, query string, args ...interface{}) error {
+	to_mask := maskColumnsByRegex(query, l.mc)
+	values := make([]string, len(args))
+	for i, arg := range args {
+		if len(to_mask) > 0 && to_mask[0] == i {
+			values[i] = "* SECRET *"
+			to_mask = to_mask[1:]
+		} else {
+			values[i] = fmt.Sprint(arg)
+		}
+	}
+	fmt.Fprintf(&l.buf, "%s %v", query, values)
+	return nil
+}
+
+func TestAsObserverPlainLoggerFallsBackToRegexMasking(t *testing.T) {
+	l := &plainLogger{}
+	l.AddColumnMask("password")
+	obs := AsObserver(l)
+
+	obs.AfterQuery(context.Background(), "UPDATE `users` SET `password` = ?",
+		[]string{"password"}, []interface{}{"hunter2"}, 1, nil, time.Millisecond)
+
+	if strings.Contains(l.buf.String(), "hunter2") {
+		t.Errorf("expected password arg to be masked via the regex path, got %q", l.buf.String())
+	}
+}
+
+func TestAsObserverColumnLoggerMasksBySuppliedColumns(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger(&buf)
+	l.AddColumnMask("password")
+	obs := AsObserver(l)
+
+	// Double-quoted identifiers that maskColumnsByRegex can't parse: without
+	// the columns list this would leak the secret.
+	query := `UPDATE "users" SET "password" = $1 WHERE "id" = $2`
+	obs.AfterQuery(context.Background(), query, []string{"password", "id"},
+		[]interface{}{"hunter2", 1}, 1, nil, time.Millisecond)
+
+	var rec jsonRecord
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &rec); err != nil {
+		t.Fatalf("output is not valid JSON: %v (%q)", err, buf.String())
+	}
+	if !rec.Masked {
+		t.Errorf("expected Masked = true, got false")
+	}
+	if rec.Args[0] != "* SECRET *" {
+		t.Errorf("Args[0] = %v, want masked placeholder", rec.Args[0])
+	}
+}
@@ -0,0 +1,118 @@
+package genmai
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SlogLogger is a logger that forwards each query as a structured
+// slog.Record instead of rendering a text/template.
+// It implements the logger interface.
+type SlogLogger struct {
+	l  *slog.Logger
+	m  sync.Mutex
+	s  float64
+	mc []string
+}
+
+// NewSlogLogger returns a new SlogLogger that writes records through l.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	return &SlogLogger{l: l}
+}
+
+// NewSlogHandlerLogger returns a new SlogLogger that writes records through
+// h, e.g. a user's existing JSON or logfmt slog.Handler.
+func NewSlogHandlerLogger(h slog.Handler) *SlogLogger {
+	return NewSlogLogger(slog.New(h))
+}
+
+// SetFormat is unused by SlogLogger: the record shape is fixed so that it
+// stays machine-parseable. It always returns nil.
+func (l *SlogLogger) SetFormat(format string) error {
+	return nil
+}
+
+// SetSlowTime sets slow query threshold. Queries at or above slow are
+// emitted at slog.LevelWarn instead of slog.LevelDebug.
+func (l *SlogLogger) SetSlowTime(slow float64) error {
+	l.s = slow
+	return nil
+}
+
+// AddColumnMask sets column to be masked.
+func (l *SlogLogger) AddColumnMask(mask string) {
+	l.mc = append(l.mc, mask)
+}
+
+// RemoveColumnMask remove column to be masked.
+func (l *SlogLogger) RemoveColumnMask(mask string) {
+	for i, v := range l.mc {
+		if v == mask {
+			l.mc = append(l.mc[:i], l.mc[i+1:]...)
+			return
+		}
+	}
+}
+
+// Print outputs a query as a structured slog.Record.
+func (l *SlogLogger) Print(start time.Time, query string, args ...interface{}) error {
+	return l.print(start, query, maskColumnsByRegex(query, l.mc), args...)
+}
+
+// PrintColumns is like Print, but masks args by matching columns - the
+// column name bound to each placeholder, as recorded by the query builder -
+// against the secret columns registered via AddColumnMask/RegisterModel,
+// instead of re-parsing query with regexes.
+func (l *SlogLogger) PrintColumns(start time.Time, query string, columns []string, args ...interface{}) error {
+	if len(columns) == 0 {
+		return l.Print(start, query, args...)
+	}
+	return l.print(start, query, maskColumnsByName(columns, l.mc), args...)
+}
+
+func (l *SlogLogger) print(start time.Time, query string, to_mask []int, args ...interface{}) error {
+	duration := now().Sub(start).Seconds() * 1000.0
+	slow := l.s > 0.0 && duration >= l.s
+
+	level := slog.LevelDebug
+	if slow {
+		level = slog.LevelWarn
+	}
+	if !l.l.Enabled(context.Background(), level) {
+		return nil
+	}
+
+	op := queryOperation(query)
+	attrs := []slog.Attr{
+		slog.String("query", query),
+		slog.Float64("duration_ms", duration),
+		slog.Bool("slow", slow),
+	}
+	if op != "" {
+		attrs = append(attrs, slog.String("operation", op))
+	}
+	if table := queryTable(op, query); table != "" {
+		attrs = append(attrs, slog.String("table", table))
+	}
+	if len(args) > 0 {
+		argAttrs := make([]any, len(args))
+		for i, arg := range args {
+			name := "arg" + strconv.Itoa(i)
+			if len(to_mask) > 0 && to_mask[0] == i {
+				argAttrs[i] = slog.String(name, "* SECRET *")
+				to_mask = to_mask[1:]
+			} else {
+				argAttrs[i] = slog.Any(name, arg)
+			}
+		}
+		attrs = append(attrs, slog.Group("args", argAttrs...))
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.l.LogAttrs(context.Background(), level, "genmai query", attrs...)
+	return nil
+}
@@ -0,0 +1,100 @@
+package genmai
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSlogLogger(buf *bytes.Buffer) *SlogLogger {
+	h := slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return NewSlogHandlerLogger(h)
+}
+
+func TestSlogLoggerDebugLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestSlogLogger(&buf)
+	l.SetSlowTime(100)
+
+	restore := now
+	now = func() time.Time { return time.Unix(0, 0).Add(5 * time.Millisecond) }
+	defer func() { now = restore }()
+
+	if err := l.Print(time.Unix(0, 0), "SELECT * FROM `users` WHERE `id` = ?", 1); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "level=DEBUG") {
+		t.Errorf("expected non-slow query at level=DEBUG, got %q", out)
+	}
+	if !strings.Contains(out, "slow=false") {
+		t.Errorf("expected slow=false, got %q", out)
+	}
+	if !strings.Contains(out, "operation=SELECT") || !strings.Contains(out, "table=users") {
+		t.Errorf("expected operation/table attrs, got %q", out)
+	}
+}
+
+func TestSlogLoggerWarnLevelWhenSlow(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestSlogLogger(&buf)
+	l.SetSlowTime(1)
+
+	restore := now
+	now = func() time.Time { return time.Unix(0, 0).Add(5 * time.Millisecond) }
+	defer func() { now = restore }()
+
+	if err := l.Print(time.Unix(0, 0), "SELECT * FROM `users`"); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "level=WARN") {
+		t.Errorf("expected slow query at level=WARN, got %q", out)
+	}
+	if !strings.Contains(out, "slow=true") {
+		t.Errorf("expected slow=true, got %q", out)
+	}
+}
+
+func TestSlogLoggerMasksSecretColumns(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestSlogLogger(&buf)
+	l.AddColumnMask("password")
+
+	restore := now
+	now = func() time.Time { return time.Unix(0, 0) }
+	defer func() { now = restore }()
+
+	query := "INSERT INTO `users` (`name`, `password`) VALUES (?, ?)"
+	if err := l.Print(time.Unix(0, 0), query, "bob", "hunter2"); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("expected password arg to be masked, got %q", out)
+	}
+	if !strings.Contains(out, "* SECRET *") {
+		t.Errorf("expected masked placeholder in output, got %q", out)
+	}
+}
+
+func TestSlogLoggerPrintColumnsUsesRegisteredSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestSlogLogger(&buf)
+	l.AddColumnMask("password")
+
+	restore := now
+	now = func() time.Time { return time.Unix(0, 0) }
+	defer func() { now = restore }()
+
+	columns := []string{"name", "password"}
+	if err := l.PrintColumns(time.Unix(0, 0), `SELECT * FROM "users" WHERE "name" = $1 AND "password" = $2`, columns, "bob", "hunter2"); err != nil {
+		t.Fatalf("PrintColumns() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("expected password arg to be masked via columns, got %q", out)
+	}
+}